@@ -0,0 +1,445 @@
+// Package container provides a fixture.Fixture implementation that starts a
+// Docker container for the duration of a test (or a whole suite) and tears
+// it down afterwards. It talks to the Docker Engine API directly over the
+// daemon's unix socket, so no additional dependency is required.
+package container
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/halimath/fixture"
+)
+
+// defaultDockerHost is the unix socket the Docker Engine API listens on by
+// default on Linux and macOS (via Docker Desktop's socket forwarding).
+const defaultDockerHost = "/var/run/docker.sock"
+
+// ContainerFixture is a fixture.Fixture that starts a Docker container in
+// BeforeAllContext and removes it in AfterAllContext, exposing whatever
+// ports the container publishes under container-assigned host ports (the
+// Docker equivalent of httptest.Server listening on ":0").
+//
+// ContainerFixture uses the context-aware hooks so a container pull or
+// create that would otherwise dwarf a test's -timeout is aborted cleanly
+// instead of leaking a container past the end of the test run.
+type ContainerFixture struct {
+	// Image is the image reference to run, e.g. "postgres:16-alpine".
+	Image string
+
+	// Env holds the environment variables passed to the container.
+	Env map[string]string
+
+	// ExposedPorts lists the container ports to publish to the host, each
+	// in "<port>/<proto>" form, e.g. "5432/tcp". Docker picks a free host
+	// port for each one; use Port to look it up once the container is
+	// running.
+	ExposedPorts []string
+
+	// WaitStrategy is consulted after the container has started and before
+	// BeforeAllContext returns, to block until the container is actually
+	// ready to serve requests. A nil WaitStrategy means the container is
+	// considered ready as soon as it reports a running state.
+	WaitStrategy WaitStrategy
+
+	// DockerHost overrides the unix socket path used to reach the Docker
+	// Engine API. Defaults to defaultDockerHost.
+	DockerHost string
+
+	client *dockerClient
+	id     string
+	ports  map[string]string
+}
+
+// BeforeAllContext implements fixture.BeforeAllContext. It pulls Image
+// (on every call, even if the image is already present), creates and starts the container, and blocks until
+// WaitStrategy (if set) reports the container is ready. ctx cancellation
+// aborts the pull/create/wait so a misbehaving image fails the test instead
+// of hanging until go test -timeout kills the run.
+func (f *ContainerFixture) BeforeAllContext(ctx context.Context, t *testing.T) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	host := f.DockerHost
+	if host == "" {
+		host = defaultDockerHost
+	}
+	f.client = newDockerClient(host)
+
+	if err := f.client.pullImage(ctx, f.Image); err != nil {
+		return fmt.Errorf("container: failed to pull image %s: %w", f.Image, err)
+	}
+
+	id, err := f.client.createContainer(ctx, f.Image, f.Env, f.ExposedPorts)
+	if err != nil {
+		return fmt.Errorf("container: failed to create container for image %s: %w", f.Image, err)
+	}
+	f.id = id
+
+	if err := f.client.startContainer(ctx, f.id); err != nil {
+		return fmt.Errorf("container: failed to start container %s: %w", f.id, err)
+	}
+
+	ports, err := f.client.mappedPorts(ctx, f.id)
+	if err != nil {
+		return fmt.Errorf("container: failed to determine mapped ports for container %s: %w", f.id, err)
+	}
+	f.ports = ports
+
+	if f.WaitStrategy != nil {
+		if err := f.WaitStrategy.Wait(ctx, f); err != nil {
+			return fmt.Errorf("container: wait strategy for %s did not succeed: %w", f.Image, err)
+		}
+	}
+
+	return nil
+}
+
+// AfterAllContext implements fixture.AfterAllContext. It stops and removes
+// the container unconditionally, ignoring ctx - removeContainer runs
+// teardown on its own bounded context instead (see there for why).
+func (f *ContainerFixture) AfterAllContext(ctx context.Context, t *testing.T) error {
+	if f.id == "" {
+		return nil
+	}
+
+	if err := f.client.removeContainer(f.id); err != nil {
+		return fmt.Errorf("container: failed to remove container %s: %w", f.id, err)
+	}
+
+	return nil
+}
+
+// Port returns the host port Docker mapped containerPort (in "<port>/<proto>"
+// form, e.g. "5432/tcp") to. It panics if Docker did not map containerPort
+// to a host port, e.g. because it was neither listed in ExposedPorts nor
+// exposed by the image, since that is a programming error rather than a
+// runtime condition a test should handle.
+func (f *ContainerFixture) Port(containerPort string) string {
+	port, ok := f.ports[containerPort]
+	if !ok {
+		panic(fmt.Sprintf("container: port %s was not exposed", containerPort))
+	}
+	return port
+}
+
+// Endpoint returns a "host:port" endpoint for containerPort, suitable for
+// dialing the container from the test process.
+func (f *ContainerFixture) Endpoint(containerPort string) string {
+	return net.JoinHostPort("localhost", f.Port(containerPort))
+}
+
+// URL returns an http URL for containerPort with path appended verbatim to
+// the "host:port" endpoint; no separating slash is inserted.
+func (f *ContainerFixture) URL(containerPort, path string) string {
+	return "http://" + f.Endpoint(containerPort) + path
+}
+
+// logs returns the container's combined stdout/stderr log so far. It is
+// used by WaitForLog and is also handy when a WaitStrategy fails and a test
+// wants to report what the container printed.
+func (f *ContainerFixture) logs(ctx context.Context) ([]byte, error) {
+	return f.client.containerLogs(ctx, f.id)
+}
+
+// WaitStrategy determines when a started container is actually ready to
+// serve requests. Wait is called once after the container reports a running
+// state and should block until ready, returning ctx.Err() (or a wrapped
+// version of it) if ctx is canceled first.
+type WaitStrategy interface {
+	Wait(ctx context.Context, f *ContainerFixture) error
+}
+
+// waitStrategyFunc adapts a plain function to WaitStrategy.
+type waitStrategyFunc func(ctx context.Context, f *ContainerFixture) error
+
+func (w waitStrategyFunc) Wait(ctx context.Context, f *ContainerFixture) error {
+	return w(ctx, f)
+}
+
+// pollInterval is the time waited between two readiness checks performed by
+// the built-in WaitStrategy implementations.
+const pollInterval = 100 * time.Millisecond
+
+// WaitForTCP returns a WaitStrategy that succeeds as soon as a TCP
+// connection to containerPort can be established.
+func WaitForTCP(containerPort string) WaitStrategy {
+	return waitStrategyFunc(func(ctx context.Context, f *ContainerFixture) error {
+		return poll(ctx, func() bool {
+			conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", f.Endpoint(containerPort))
+			if err != nil {
+				return false
+			}
+			conn.Close()
+			return true
+		})
+	})
+}
+
+// WaitForHTTP returns a WaitStrategy that succeeds as soon as a GET request
+// against path on containerPort answers with a 2xx status code.
+func WaitForHTTP(containerPort, path string) WaitStrategy {
+	return waitStrategyFunc(func(ctx context.Context, f *ContainerFixture) error {
+		return poll(ctx, func() bool {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.URL(containerPort, path), nil)
+			if err != nil {
+				return false
+			}
+
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				return false
+			}
+			res.Body.Close()
+
+			return res.StatusCode >= 200 && res.StatusCode < 300
+		})
+	})
+}
+
+// WaitForLog returns a WaitStrategy that succeeds as soon as the
+// container's combined stdout/stderr log contains match as a substring.
+// This is the fallback for containers/images that expose no port to probe,
+// such as a one-shot migration runner that simply logs "done".
+func WaitForLog(match string) WaitStrategy {
+	return waitStrategyFunc(func(ctx context.Context, f *ContainerFixture) error {
+		return poll(ctx, func() bool {
+			logs, err := f.logs(ctx)
+			if err != nil {
+				return false
+			}
+			return bytes.Contains(logs, []byte(match))
+		})
+	})
+}
+
+// poll calls ready every pollInterval until it returns true or ctx is
+// canceled, in which case ctx.Err() is returned.
+func poll(ctx context.Context, ready func() bool) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	if ready() {
+		return nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if ready() {
+				return nil
+			}
+		}
+	}
+}
+
+// dockerClient is a minimal client for the parts of the Docker Engine API
+// ContainerFixture needs, talking to the daemon over its unix socket.
+type dockerClient struct {
+	http *http.Client
+}
+
+func newDockerClient(socketPath string) *dockerClient {
+	return &dockerClient{
+		http: &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					return (&net.Dialer{}).DialContext(ctx, "unix", socketPath)
+				},
+			},
+		},
+	}
+}
+
+// do sends a request built from method, path and body (which is JSON
+// encoded if non-nil) and fails unless the response status is one of
+// wantStatuses.
+func (c *dockerClient) do(ctx context.Context, method, path string, body any, wantStatuses ...int) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request body: %w", err)
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, "http://docker"+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request for %s %s: %w", method, path, err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach docker daemon for %s %s: %w", method, path, err)
+	}
+
+	if !slices.Contains(wantStatuses, res.StatusCode) {
+		defer res.Body.Close()
+		msg, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("docker daemon answered %s %s with %d: %s", method, path, res.StatusCode, msg)
+	}
+
+	return res, nil
+}
+
+func (c *dockerClient) pullImage(ctx context.Context, image string) error {
+	res, err := c.do(ctx, http.MethodPost, "/images/create?fromImage="+image, nil, http.StatusOK)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	// The response is a stream of JSON progress objects; draining it is
+	// enough to make sure the pull has completed.
+	_, err = io.Copy(io.Discard, res.Body)
+	return err
+}
+
+// createContainerRequest mirrors the subset of the Docker Engine API's
+// container creation payload ContainerFixture relies on.
+type createContainerRequest struct {
+	Image        string              `json:"Image"`
+	Env          []string            `json:"Env,omitempty"`
+	ExposedPorts map[string]struct{} `json:"ExposedPorts,omitempty"`
+	HostConfig   struct {
+		PublishAllPorts bool `json:"PublishAllPorts"`
+	} `json:"HostConfig"`
+}
+
+type createContainerResponse struct {
+	ID string `json:"Id"`
+}
+
+func (c *dockerClient) createContainer(ctx context.Context, image string, env map[string]string, exposedPorts []string) (string, error) {
+	req := createContainerRequest{Image: image}
+	req.HostConfig.PublishAllPorts = true
+
+	for k, v := range env {
+		req.Env = append(req.Env, k+"="+v)
+	}
+
+	if len(exposedPorts) > 0 {
+		req.ExposedPorts = make(map[string]struct{}, len(exposedPorts))
+		for _, p := range exposedPorts {
+			req.ExposedPorts[p] = struct{}{}
+		}
+	}
+
+	res, err := c.do(ctx, http.MethodPost, "/containers/create", req, http.StatusCreated)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	var decoded createContainerResponse
+	if err := json.NewDecoder(res.Body).Decode(&decoded); err != nil {
+		return "", fmt.Errorf("failed to decode create container response: %w", err)
+	}
+
+	return decoded.ID, nil
+}
+
+func (c *dockerClient) startContainer(ctx context.Context, id string) error {
+	res, err := c.do(ctx, http.MethodPost, "/containers/"+id+"/start", nil, http.StatusNoContent)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	return nil
+}
+
+// inspectResponse mirrors the subset of the Docker Engine API's container
+// inspect payload ContainerFixture relies on.
+type inspectResponse struct {
+	NetworkSettings struct {
+		Ports map[string][]struct {
+			HostPort string `json:"HostPort"`
+		} `json:"Ports"`
+	} `json:"NetworkSettings"`
+}
+
+func (c *dockerClient) mappedPorts(ctx context.Context, id string) (map[string]string, error) {
+	res, err := c.do(ctx, http.MethodGet, "/containers/"+id+"/json", nil, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var decoded inspectResponse
+	if err := json.NewDecoder(res.Body).Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("failed to decode inspect response: %w", err)
+	}
+
+	ports := make(map[string]string, len(decoded.NetworkSettings.Ports))
+	for containerPort, bindings := range decoded.NetworkSettings.Ports {
+		if len(bindings) > 0 {
+			ports[containerPort] = bindings[0].HostPort
+		}
+	}
+
+	return ports, nil
+}
+
+func (c *dockerClient) containerLogs(ctx context.Context, id string) ([]byte, error) {
+	res, err := c.do(ctx, http.MethodGet, "/containers/"+id+"/logs?stdout=1&stderr=1", nil, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return io.ReadAll(res.Body)
+}
+
+func (c *dockerClient) removeContainer(id string) error {
+	// Stopping/removing a container during test teardown must not be tied
+	// to the (possibly already expired) test context, so a fresh
+	// background context with its own bound is used instead.
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// StatusNotModified is returned when the container was already stopped
+	// (e.g. it crashed before teardown ran); that's not an error here, and
+	// treating it as one would skip the delete below and leak the container.
+	res, err := c.do(ctx, http.MethodPost, "/containers/"+id+"/stop", nil, http.StatusNoContent, http.StatusNotModified)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+
+	res, err = c.do(ctx, http.MethodDelete, "/containers/"+id, nil, http.StatusNoContent)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+
+	return nil
+}
+
+// MultiContainer combines several fixtures - typically ContainerFixtures -
+// into a single fixture.Fixture that starts them first-to-last and tears
+// them down last-to-first, by delegating to fixture.MultiFixture. Use it to
+// express compose-style dependencies between services, e.g. a database
+// that must be up before the application under test starts:
+//
+//	container.MultiContainer(pg, app)
+//
+// For exactly two fixtures, fixture.Tuple additionally exposes them as
+// statically typed One/Two fields and may read more naturally.
+func MultiContainer(fixtures ...fixture.Fixture) fixture.MultiFixture {
+	return fixture.MultiFixture(fixtures)
+}
@@ -0,0 +1,68 @@
+package container_test
+
+import (
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/halimath/fixture"
+	"github.com/halimath/fixture/container"
+)
+
+// skipWithoutDocker skips the test unless the default Docker unix socket
+// exists, since these tests pull and run a real image.
+func skipWithoutDocker(t *testing.T) {
+	t.Helper()
+
+	if _, err := os.Stat("/var/run/docker.sock"); err != nil {
+		t.Skip("docker daemon not available, skipping container test")
+	}
+}
+
+// TestPostgresExample starts an official Postgres image, waits until its log
+// reports that the database system is ready to accept connections, and runs two subtests that share the
+// same TCP connection opened against the mapped port.
+func TestPostgresExample(t *testing.T) {
+	skipWithoutDocker(t)
+
+	pg := &container.ContainerFixture{
+		Image: "postgres:16-alpine",
+		Env: map[string]string{
+			"POSTGRES_PASSWORD": "test",
+			"POSTGRES_DB":       "testdb",
+		},
+		ExposedPorts: []string{"5432/tcp"},
+		WaitStrategy: container.WaitForLog("database system is ready to accept connections"),
+	}
+
+	var conn net.Conn
+
+	fixture.With(t, pg).
+		Run("connect", func(t *testing.T, pg *container.ContainerFixture) {
+			var err error
+			conn, err = net.DialTimeout("tcp", pg.Endpoint("5432/tcp"), 5*time.Second)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}).
+		Run("connection survives into the next subtest", func(t *testing.T, pg *container.ContainerFixture) {
+			if conn == nil {
+				t.Fatal("expected the previous subtest to have opened a connection")
+			}
+
+			if err := conn.SetDeadline(time.Now().Add(time.Second)); err != nil {
+				t.Fatal(err)
+			}
+
+			// Writing a raw byte must still succeed on the connection the
+			// previous subtest opened.
+			if _, err := conn.Write([]byte{0}); err != nil {
+				t.Errorf("connection from the previous subtest is no longer usable: %s", err)
+			}
+		})
+
+	if conn != nil {
+		conn.Close()
+	}
+}
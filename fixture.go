@@ -8,6 +8,7 @@
 package fixture
 
 import (
+	"context"
 	"sync"
 	"testing"
 )
@@ -40,13 +41,104 @@ type AfterEach interface {
 	AfterEach(t *testing.T) error
 }
 
+// BeforeAllContext is the context-aware variant of BeforeAll. The context is
+// derived from the test's deadline (see testing.T.Deadline) and canceled
+// once the test completes, so long-running setup - spinning up an
+// httptest server behind TLS, waiting for a container's health check,
+// seeding a database - cancels cleanly when go test -timeout fires or a
+// subtest fails fast. If a fixture implements both BeforeAll and
+// BeforeAllContext, the context variant takes precedence.
+type BeforeAllContext interface {
+	Fixture
+	BeforeAllContext(ctx context.Context, t *testing.T) error
+}
+
+// AfterAllContext is the context-aware variant of AfterAll. See
+// BeforeAllContext for how the context is derived.
+type AfterAllContext interface {
+	Fixture
+	AfterAllContext(ctx context.Context, t *testing.T) error
+}
+
+// BeforeEachContext is the context-aware variant of BeforeEach. See
+// BeforeAllContext for how the context is derived.
+type BeforeEachContext interface {
+	BeforeEachContext(ctx context.Context, t *testing.T) error
+}
+
+// AfterEachContext is the context-aware variant of AfterEach. See
+// BeforeAllContext for how the context is derived.
+type AfterEachContext interface {
+	AfterEachContext(ctx context.Context, t *testing.T) error
+}
+
+// deadlineContext derives a context.Context from t's deadline and registers
+// its cancellation via t.Cleanup. If t has no deadline (e.g. go test ran
+// without -timeout), the returned context is context.Background() and never
+// canceled by this function.
+func deadlineContext(t *testing.T) context.Context {
+	t.Helper()
+
+	deadline, ok := t.Deadline()
+	if !ok {
+		return context.Background()
+	}
+
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	t.Cleanup(cancel)
+
+	return ctx
+}
+
+// Cloneable is an optional interface a fixture may implement to obtain an
+// independent copy of itself for every parallel subtest started via
+// ParallelRun (or Run when the suite was created with WithParallel).
+// Implement Cloneable whenever a fixture carries per-test mutable state
+// that must not be shared between goroutines, such as TempDirFixture.
+// Fixtures that are safe to share, such as HTTPServerFixture, can omit it;
+// in that case the same instance is handed to every parallel subtest and a
+// warning documenting that is logged via t.Logf.
+type Cloneable interface {
+	Fixture
+	Clone() Fixture
+}
+
 // TestFunc defines the type for test functions running a test on behalf of
 // a Fixture.
 type TestFunc[F Fixture] func(*testing.T, F)
 
 // Suite defines a suite of tests using the same fixture.
 type Suite[F Fixture] interface {
+	// Run registers another test with name to run sequentially, or as a
+	// parallel subtest like ParallelRun if the suite was created with
+	// WithParallel.
 	Run(string, TestFunc[F]) Suite[F]
+
+	// ParallelRun registers another test with name to run as a parallel
+	// subtest, i.e. it calls t.Parallel() before invoking test. BeforeEach
+	// runs inside the subtest goroutine on the subtest's *testing.T and
+	// AfterEach is registered via t.Cleanup on that same subtest, so both
+	// hooks fire at the right time regardless of when the parallel subtest
+	// actually executes. If the fixture implements Cloneable, ParallelRun
+	// hands each subtest its own copy obtained via Clone.
+	ParallelRun(string, TestFunc[F]) Suite[F]
+}
+
+// Option configures a Suite created via With.
+type Option func(*suiteOptions)
+
+// suiteOptions holds the options applied by With.
+type suiteOptions struct {
+	parallel bool
+}
+
+// WithParallel makes every test registered via Run behave like ParallelRun,
+// i.e. it runs as a parallel subtest. ParallelRun itself is unaffected by
+// this option since it always runs in parallel.
+func WithParallel() Option {
+	return func(o *suiteOptions) {
+		o.parallel = true
+	}
 }
 
 // suiteBuilder is an implementation of a Suite.
@@ -55,6 +147,7 @@ type suiteBuilder[F Fixture] struct {
 	t              *testing.T
 	testRun        bool
 	afterAllRunner sync.Once
+	parallel       bool
 }
 
 // Run registers another test with name to run using the fixture contained in f.
@@ -62,12 +155,35 @@ type suiteBuilder[F Fixture] struct {
 // executes test on behalf of f.t.
 func (f *suiteBuilder[F]) Run(name string, test TestFunc[F]) Suite[F] {
 	f.t.Helper()
+	return f.run(name, test, f.parallel)
+}
+
+// ParallelRun registers another test with name to run as a parallel subtest.
+// See Suite.ParallelRun for the details.
+func (f *suiteBuilder[F]) ParallelRun(name string, test TestFunc[F]) Suite[F] {
+	f.t.Helper()
+	return f.run(name, test, true)
+}
+
+// run implements the shared logic behind Run and ParallelRun.
+func (f *suiteBuilder[F]) run(name string, test TestFunc[F], parallel bool) Suite[F] {
+	f.t.Helper()
 
 	var fix any = f.f
 
-	if aa, ok := fix.(AfterAll); ok {
-		f.afterAllRunner.Do(func() {
-			f.t.Helper()
+	f.afterAllRunner.Do(func() {
+		f.t.Helper()
+
+		if aac, ok := fix.(AfterAllContext); ok {
+			ctx := deadlineContext(f.t)
+			f.t.Cleanup(func() {
+				f.t.Helper()
+
+				if err := aac.AfterAllContext(ctx, f.t); err != nil {
+					f.t.Fatal(err)
+				}
+			})
+		} else if aa, ok := fix.(AfterAll); ok {
 			f.t.Cleanup(func() {
 				f.t.Helper()
 
@@ -75,11 +191,15 @@ func (f *suiteBuilder[F]) Run(name string, test TestFunc[F]) Suite[F] {
 					f.t.Fatal(err)
 				}
 			})
-		})
-	}
+		}
+	})
 
 	if !f.testRun {
-		if ba, ok := fix.(BeforeAll); ok {
+		if bac, ok := fix.(BeforeAllContext); ok {
+			if err := bac.BeforeAllContext(deadlineContext(f.t), f.t); err != nil {
+				f.t.Fatal(err)
+			}
+		} else if ba, ok := fix.(BeforeAll); ok {
 			if err := ba.BeforeAll(f.t); err != nil {
 				f.t.Fatal(err)
 			}
@@ -87,30 +207,70 @@ func (f *suiteBuilder[F]) Run(name string, test TestFunc[F]) Suite[F] {
 	}
 	f.testRun = true
 
-	if ba, ok := fix.(BeforeEach); ok {
-		if err := ba.BeforeEach(f.t); err != nil {
-			f.t.Fatal(err)
+	testFixture := f.f
+	if parallel {
+		if c, ok := fix.(Cloneable); ok {
+			testFixture = c.Clone().(F)
+		} else {
+			f.t.Logf("fixture %T does not implement Cloneable; sharing a single instance across parallel subtests", f.f)
 		}
 	}
 
 	f.t.Run(name, func(t *testing.T) {
-		test(t, f.f)
-	})
+		t.Helper()
 
-	if ba, ok := fix.(AfterEach); ok {
-		if err := ba.AfterEach(f.t); err != nil {
-			f.t.Fatal(err)
+		if parallel {
+			t.Parallel()
+		}
+
+		var testFix any = testFixture
+
+		if bec, ok := testFix.(BeforeEachContext); ok {
+			if err := bec.BeforeEachContext(deadlineContext(t), t); err != nil {
+				t.Fatal(err)
+			}
+		} else if be, ok := testFix.(BeforeEach); ok {
+			if err := be.BeforeEach(t); err != nil {
+				t.Fatal(err)
+			}
 		}
-	}
+
+		if aec, ok := testFix.(AfterEachContext); ok {
+			ctx := deadlineContext(t)
+			t.Cleanup(func() {
+				t.Helper()
+
+				if err := aec.AfterEachContext(ctx, t); err != nil {
+					t.Fatal(err)
+				}
+			})
+		} else if ae, ok := testFix.(AfterEach); ok {
+			t.Cleanup(func() {
+				t.Helper()
+
+				if err := ae.AfterEach(t); err != nil {
+					t.Fatal(err)
+				}
+			})
+		}
+
+		test(t, testFixture)
+	})
 
 	return f
 }
 
 // With is used to define a new Suite based on fixture.
-func With[F any](t *testing.T, fixture F) Suite[F] {
+func With[F any](t *testing.T, fixture F, opts ...Option) Suite[F] {
+	var o suiteOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	return &suiteBuilder[F]{
-		f: fixture,
-		t: t,
+		f:        fixture,
+		t:        t,
+		parallel: o.parallel,
 	}
 }
 
@@ -144,6 +304,42 @@ func (f *TupleFixture[A, B]) AfterEach(t *testing.T) error {
 	return f.m.AfterEach(t)
 }
 
+func (f *TupleFixture[A, B]) BeforeAllContext(ctx context.Context, t *testing.T) error {
+	f.m = MultiFixture{f.One, f.Two}
+	return f.m.BeforeAllContext(ctx, t)
+}
+
+func (f *TupleFixture[A, B]) AfterAllContext(ctx context.Context, t *testing.T) error {
+	return f.m.AfterAllContext(ctx, t)
+}
+
+func (f *TupleFixture[A, B]) BeforeEachContext(ctx context.Context, t *testing.T) error {
+	return f.m.BeforeEachContext(ctx, t)
+}
+
+func (f *TupleFixture[A, B]) AfterEachContext(ctx context.Context, t *testing.T) error {
+	return f.m.AfterEachContext(ctx, t)
+}
+
+// Clone implements Cloneable. It clones One and Two individually if they
+// implement Cloneable themselves and otherwise shares them, so a
+// TupleFixture combining a cloneable and a shareable fixture works as
+// expected with ParallelRun.
+func (f *TupleFixture[A, B]) Clone() Fixture {
+	clone := &TupleFixture[A, B]{One: f.One, Two: f.Two}
+
+	if c, ok := any(f.One).(Cloneable); ok {
+		clone.One = c.Clone().(A)
+	}
+	if c, ok := any(f.Two).(Cloneable); ok {
+		clone.Two = c.Clone().(B)
+	}
+
+	clone.m = MultiFixture{clone.One, clone.Two}
+
+	return clone
+}
+
 // MultiFixture combines multiple fixtures into a single one to use with With.
 // It implements every hook interface and delegates all hooks to each fixture.
 // The order of delegation is defined by the hooks type:
@@ -195,3 +391,85 @@ func (f MultiFixture) AfterEach(t *testing.T) error {
 	}
 	return nil
 }
+
+func (f MultiFixture) BeforeAllContext(ctx context.Context, t *testing.T) error {
+	for i := range f {
+		if h, ok := f[i].(BeforeAllContext); ok {
+			if err := h.BeforeAllContext(ctx, t); err != nil {
+				return err
+			}
+			continue
+		}
+		if h, ok := f[i].(BeforeAll); ok {
+			if err := h.BeforeAll(t); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (f MultiFixture) AfterAllContext(ctx context.Context, t *testing.T) error {
+	for i := len(f) - 1; i >= 0; i-- {
+		if h, ok := f[i].(AfterAllContext); ok {
+			if err := h.AfterAllContext(ctx, t); err != nil {
+				return err
+			}
+			continue
+		}
+		if h, ok := f[i].(AfterAll); ok {
+			if err := h.AfterAll(t); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (f MultiFixture) BeforeEachContext(ctx context.Context, t *testing.T) error {
+	for i := range f {
+		if h, ok := f[i].(BeforeEachContext); ok {
+			if err := h.BeforeEachContext(ctx, t); err != nil {
+				return err
+			}
+			continue
+		}
+		if h, ok := f[i].(BeforeEach); ok {
+			if err := h.BeforeEach(t); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (f MultiFixture) AfterEachContext(ctx context.Context, t *testing.T) error {
+	for i := len(f) - 1; i >= 0; i-- {
+		if h, ok := f[i].(AfterEachContext); ok {
+			if err := h.AfterEachContext(ctx, t); err != nil {
+				return err
+			}
+			continue
+		}
+		if h, ok := f[i].(AfterEach); ok {
+			if err := h.AfterEach(t); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Clone implements Cloneable, cloning every element that implements
+// Cloneable itself and sharing the rest.
+func (f MultiFixture) Clone() Fixture {
+	clone := make(MultiFixture, len(f))
+	for i := range f {
+		if c, ok := f[i].(Cloneable); ok {
+			clone[i] = c.Clone()
+		} else {
+			clone[i] = f[i]
+		}
+	}
+	return clone
+}
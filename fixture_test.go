@@ -1,7 +1,10 @@
 package fixture_test
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"os/exec"
 	"strings"
 	"testing"
 
@@ -79,3 +82,68 @@ func TestFixture_tupleFixture(t *testing.T) {
 		t.Errorf("\nexpected %q\n but got %q", want, got)
 	}
 }
+
+// contextCapturingFixture records the ctx it receives in BeforeAllContext
+// so a test can inspect it once the fixture's lifecycle has run.
+type contextCapturingFixture struct {
+	ctx context.Context
+}
+
+func (f *contextCapturingFixture) BeforeAllContext(ctx context.Context, t *testing.T) error {
+	f.ctx = ctx
+	return nil
+}
+
+func (f *contextCapturingFixture) AfterAllContext(ctx context.Context, t *testing.T) error {
+	return nil
+}
+
+// deadlineContextSubprocessEnv switches TestFixture_deadlineContext into the
+// subprocess role once re-exec'd with a -test.timeout flag, see below.
+const deadlineContextSubprocessEnv = "FIXTURE_TEST_DEADLINE_CONTEXT_SUBPROCESS"
+
+// TestFixture_deadlineContext verifies that the context passed to a
+// fixture's *Context hooks is derived from the test's deadline and canceled
+// once the test completes. testing.T.Deadline only reports a deadline when
+// the test binary was run with -timeout, so this test re-execs itself with
+// that flag set and does the actual assertions in the child process.
+func TestFixture_deadlineContext(t *testing.T) {
+	if os.Getenv(deadlineContextSubprocessEnv) == "1" {
+		runDeadlineContextSubtest(t)
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestFixture_deadlineContext$", "-test.timeout=30s")
+	cmd.Env = append(os.Environ(), deadlineContextSubprocessEnv+"=1")
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("subprocess run with -test.timeout failed: %s\n%s", err, out)
+	}
+}
+
+func runDeadlineContextSubtest(t *testing.T) {
+	f := &contextCapturingFixture{}
+
+	t.Run("test", func(t *testing.T) {
+		With(t, f).
+			Run("test", func(t *testing.T, f *contextCapturingFixture) {})
+	})
+
+	if f.ctx == nil {
+		t.Fatal("expected BeforeAllContext to have captured a context")
+	}
+
+	if _, ok := f.ctx.Deadline(); !ok {
+		t.Fatal("expected the captured context to carry a deadline derived from t.Deadline()")
+	}
+
+	select {
+	case <-f.ctx.Done():
+	default:
+		t.Fatal("expected the captured context to be canceled once the outer test completed")
+	}
+
+	if err := f.ctx.Err(); err != context.Canceled {
+		t.Errorf("expected the context to be canceled via cleanup, not via its deadline, but got %v", err)
+	}
+}
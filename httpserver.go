@@ -1,37 +1,245 @@
 package fixture
 
 import (
+	"bytes"
+	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"path"
 	"strings"
+	"sync"
 	"testing"
 )
 
+// maxRecordedBodyBytes bounds how much of a request body Record copies into
+// a RecordedRequest, so a handler receiving a huge or streaming body doesn't
+// blow up memory.
+const maxRecordedBodyBytes = 64 * 1024
+
+// maxRecordedRequests bounds how many RecordedRequest values Requests keeps
+// around; once full, recording a new request evicts the oldest one.
+const maxRecordedRequests = 256
+
+// recordOwnerHeader carries the recording subtest's t.Name() on requests
+// sent through Do, so wrapRecord can attribute a recorded request to the
+// subtest that made it instead of to whichever subtest happens to read
+// Requests next. The header is stripped before the request reaches the
+// registered handler.
+const recordOwnerHeader = "X-Fixture-Record-Owner"
+
 // HTTPServerFixture is a fixture that provides a httptest.Server for testing.
 // The server will be started on BeforeAll and closed on AfterAll. The server
 // is started with HTTP2 enabled but without TLS by default. Both can be
 // changed by setting the boolean flags on the fixture.
 type HTTPServerFixture struct {
-	mux          *http.ServeMux
-	srv          *httptest.Server
-	UseTLS       bool
-	DisableHTTP2 bool
+	mux            *http.ServeMux
+	srv            *httptest.Server
+	UseTLS         bool
+	DisableHTTP2   bool
+	record         bool
+	requests       recordedRequests
+	methodHandlers map[string]map[string]http.HandlerFunc
 }
 
 func (f *HTTPServerFixture) Handle(pattern string, handler http.Handler) {
 	if f.mux == nil {
 		f.mux = http.NewServeMux()
 	}
-	f.mux.Handle(pattern, handler)
+	f.mux.Handle(pattern, f.wrapRecord(handler))
 }
 
 func (f *HTTPServerFixture) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
 	if f.mux == nil {
 		f.mux = http.NewServeMux()
 	}
-	f.mux.HandleFunc(pattern, handler)
+	f.mux.Handle(pattern, f.wrapRecord(http.HandlerFunc(handler)))
+}
+
+// GET registers handler to answer GET requests for pattern. Unlike
+// Handle/HandleFunc, pattern can be registered for several methods, e.g. by
+// also calling POST with the same pattern; requests using any other method
+// get http.StatusMethodNotAllowed.
+func (f *HTTPServerFixture) GET(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	f.handleMethod(http.MethodGet, pattern, handler)
+}
+
+// POST registers handler to answer POST requests for pattern. See GET for
+// how multiple methods on the same pattern are handled.
+func (f *HTTPServerFixture) POST(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	f.handleMethod(http.MethodPost, pattern, handler)
+}
+
+// Mock registers a handler for pattern that always answers with status and
+// body, regardless of method. It is useful to stub out an endpoint a test
+// merely needs to exist, without writing a dedicated handler.
+func (f *HTTPServerFixture) Mock(pattern string, status int, body []byte) {
+	f.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write(body)
+	})
+}
+
+// handleMethod registers handler to serve method requests for pattern. The
+// pattern is only ever registered once with the underlying mux; further
+// calls for the same pattern but a different method add to the dispatch
+// table consulted by that single registration.
+func (f *HTTPServerFixture) handleMethod(method, pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	if f.methodHandlers == nil {
+		f.methodHandlers = make(map[string]map[string]http.HandlerFunc)
+	}
+
+	if _, ok := f.methodHandlers[pattern]; !ok {
+		f.methodHandlers[pattern] = make(map[string]http.HandlerFunc)
+		f.HandleFunc(pattern, f.dispatchMethod(pattern))
+	}
+
+	f.methodHandlers[pattern][method] = handler
+}
+
+// dispatchMethod returns a handler that looks up the request's method in
+// f.methodHandlers[pattern], answering http.StatusMethodNotAllowed if none
+// was registered.
+func (f *HTTPServerFixture) dispatchMethod(pattern string) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		handler, ok := f.methodHandlers[pattern][r.Method]
+		if !ok {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// Record enables request recording for every handler registered through
+// Handle, HandleFunc, GET, POST or Mock - including ones registered before
+// Record was called. Recorded requests are available via Requests.
+func (f *HTTPServerFixture) Record() {
+	f.record = true
+}
+
+// Requests returns the requests t itself sent through Do while Record was
+// enabled, oldest first. Attributing each recorded request to the subtest
+// that actually sent it (rather than to whichever subtest happens to read
+// Requests, or to everything recorded so far) is what keeps recorded state
+// from leaking between subtests, including parallel ones sharing the same
+// HTTPServerFixture instance (see ParallelRun) where sibling subtests may be
+// recording requests of their own at the exact same time.
+func (f *HTTPServerFixture) Requests(t *testing.T) []RecordedRequest {
+	return f.requests.forOwner(t.Name())
+}
+
+// Reset discards all requests recorded so far, regardless of which subtest
+// sent them.
+func (f *HTTPServerFixture) Reset() {
+	f.requests.reset()
+}
+
+// wrapRecord wraps handler so that, while recording is enabled via Record,
+// every request it receives is captured into f.requests before being
+// forwarded to handler with the recordOwnerHeader removed.
+func (f *HTTPServerFixture) wrapRecord(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !f.record {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		owner := r.Header.Get(recordOwnerHeader)
+		r.Header.Del(recordOwnerHeader)
+
+		body, _ := io.ReadAll(r.Body)
+		r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		recordedBody := body
+		if len(recordedBody) > maxRecordedBodyBytes {
+			recordedBody = recordedBody[:maxRecordedBodyBytes]
+		}
+
+		f.requests.add(owner, RecordedRequest{
+			Method: r.Method,
+			Path:   r.URL.Path,
+			Header: r.Header.Clone(),
+			Body:   recordedBody,
+		})
+
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// RecordedRequest captures the details of a request observed by a
+// HTTPServerFixture with recording enabled via Record.
+type RecordedRequest struct {
+	Method string
+	Path   string
+	Header http.Header
+	Body   []byte
+}
+
+// recordedEntry pairs a RecordedRequest with the t.Name() of the subtest
+// that sent it (via Do), so Requests can filter to a single subtest's own
+// requests instead of everything any subtest ever sent.
+type recordedEntry struct {
+	owner string
+	req   RecordedRequest
+}
+
+// recordedRequests is a thread-safe, fixed-size ring buffer of
+// RecordedRequest, used by HTTPServerFixture to back Requests.
+type recordedRequests struct {
+	mu     sync.Mutex
+	buf    []recordedEntry
+	next   int
+	filled bool
+}
+
+func (r *recordedRequests) add(owner string, rr RecordedRequest) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.buf == nil {
+		r.buf = make([]recordedEntry, maxRecordedRequests)
+	}
+
+	r.buf[r.next] = recordedEntry{owner: owner, req: rr}
+	r.next = (r.next + 1) % len(r.buf)
+	if r.next == 0 {
+		r.filled = true
+	}
+}
+
+// forOwner returns every still-held request sent by owner, oldest first.
+func (r *recordedRequests) forOwner(owner string) []RecordedRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ordered := make([]recordedEntry, len(r.buf))
+	n := 0
+	if !r.filled {
+		n = copy(ordered, r.buf[:r.next])
+		ordered = ordered[:n]
+	} else {
+		n = copy(ordered, r.buf[r.next:])
+		copy(ordered[n:], r.buf[:r.next])
+	}
 
+	out := make([]RecordedRequest, 0, len(ordered))
+	for _, e := range ordered {
+		if e.owner == owner {
+			out = append(out, e.req)
+		}
+	}
+	return out
+}
+
+func (r *recordedRequests) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.buf = nil
+	r.next = 0
+	r.filled = false
 }
 
 // URL returns the url used to connect to the test server formed by using the
@@ -50,7 +258,44 @@ func (f *HTTPServerFixture) URL(pathElements ...string) string {
 	return f.srv.URL + p
 }
 
-func (f *HTTPServerFixture) BeforeAll(t *testing.T) error {
+// Client returns an *http.Client configured to talk to the test server,
+// correctly set up for TLS when UseTLS is set.
+func (f *HTTPServerFixture) Client() *http.Client {
+	return f.srv.Client()
+}
+
+// Do builds a request for method and path (joined with the server's base
+// URL via URL) with the given body, sends it using Client and fails t if
+// building or sending the request returns an error. When Record is enabled,
+// the request is tagged with t.Name() so Requests(t) can attribute it to
+// the right subtest.
+func (f *HTTPServerFixture) Do(t *testing.T, method, path string, body io.Reader) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(method, f.URL(path), body)
+	if err != nil {
+		t.Fatalf("failed to build request for %s %s: %s", method, path, err)
+	}
+	if f.record {
+		req.Header.Set(recordOwnerHeader, t.Name())
+	}
+
+	res, err := f.Client().Do(req)
+	if err != nil {
+		t.Fatalf("failed to send %s %s: %s", method, path, err)
+	}
+
+	return res
+}
+
+// BeforeAllContext implements fixture.BeforeAllContext. It returns ctx.Err()
+// without starting the server if ctx has already expired; ctx is checked
+// only once, before the server is started.
+func (f *HTTPServerFixture) BeforeAllContext(ctx context.Context, t *testing.T) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	f.srv = httptest.NewUnstartedServer(f.mux)
 	f.srv.EnableHTTP2 = !f.DisableHTTP2
 
@@ -63,7 +308,8 @@ func (f *HTTPServerFixture) BeforeAll(t *testing.T) error {
 	return nil
 }
 
-func (f *HTTPServerFixture) AfterAll(t *testing.T) error {
+// AfterAllContext implements fixture.AfterAllContext.
+func (f *HTTPServerFixture) AfterAllContext(ctx context.Context, t *testing.T) error {
 	f.srv.Close()
 	return nil
 }
@@ -1,6 +1,8 @@
 package fixture
 
 import (
+	"bytes"
+	"io"
 	"net/http"
 	"testing"
 )
@@ -38,3 +40,136 @@ func TestHTTPServerFixture(t *testing.T) {
 			}
 		})
 }
+
+func TestHTTPServerFixture_record(t *testing.T) {
+	f := new(HTTPServerFixture)
+	f.Record()
+
+	f.GET("/greet", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	f.POST("/greet", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+	f.GET("/get-only", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	f.Mock("/mock", http.StatusTeapot, []byte("I'm a teapot"))
+
+	With(t, f).
+		Run("records GET and POST separately", func(t *testing.T, f *HTTPServerFixture) {
+			res := f.Do(t, http.MethodGet, "/greet", nil)
+			if res.StatusCode != http.StatusOK {
+				t.Errorf("expected 200 but got %d", res.StatusCode)
+			}
+
+			res = f.Do(t, http.MethodPost, "/greet", bytes.NewBufferString("hi"))
+			if res.StatusCode != http.StatusCreated {
+				t.Errorf("expected 201 but got %d", res.StatusCode)
+			}
+
+			reqs := f.Requests(t)
+			if len(reqs) != 2 {
+				t.Fatalf("expected 2 recorded requests but got %d", len(reqs))
+			}
+
+			if reqs[0].Method != http.MethodGet || reqs[0].Path != "/greet" {
+				t.Errorf("unexpected first recorded request: %+v", reqs[0])
+			}
+
+			if reqs[1].Method != http.MethodPost || string(reqs[1].Body) != "hi" {
+				t.Errorf("unexpected second recorded request: %+v", reqs[1])
+			}
+		}).
+		Run("Mock answers regardless of method", func(t *testing.T, f *HTTPServerFixture) {
+			res := f.Do(t, http.MethodPost, "/mock", nil)
+			if res.StatusCode != http.StatusTeapot {
+				t.Errorf("expected 418 but got %d", res.StatusCode)
+			}
+		}).
+		Run("a method not registered via GET/POST is rejected before reaching any handler", func(t *testing.T, f *HTTPServerFixture) {
+			res := f.Do(t, http.MethodDelete, "/get-only", nil)
+			if res.StatusCode != http.StatusMethodNotAllowed {
+				t.Errorf("expected 405 but got %d", res.StatusCode)
+			}
+
+			res = f.Do(t, http.MethodPost, "/get-only", nil)
+			if res.StatusCode != http.StatusMethodNotAllowed {
+				t.Errorf("expected 405 but got %d", res.StatusCode)
+			}
+		}).
+		Run("recorded requests don't leak between subtests", func(t *testing.T, f *HTTPServerFixture) {
+			if reqs := f.Requests(t); len(reqs) != 0 {
+				t.Fatalf("expected Requests to only report what this subtest itself recorded but got %d", len(reqs))
+			}
+		})
+}
+
+// TestHTTPServerFixture_recordDoesNotTruncateBody guards against a
+// regression where Record() capped the body forwarded to the handler at
+// maxRecordedBodyBytes instead of only capping the copy kept on
+// RecordedRequest.Body, silently corrupting any larger request body while
+// recording was enabled.
+func TestHTTPServerFixture_recordDoesNotTruncateBody(t *testing.T) {
+	f := new(HTTPServerFixture)
+	f.Record()
+
+	sent := bytes.Repeat([]byte("a"), maxRecordedBodyBytes+1)
+	var gotLen int
+
+	f.HandleFunc("/upload", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gotLen = len(body)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	With(t, f).
+		Run("handler receives the full body", func(t *testing.T, f *HTTPServerFixture) {
+			f.Do(t, http.MethodPost, "/upload", bytes.NewReader(sent))
+
+			if gotLen != len(sent) {
+				t.Fatalf("expected handler to see %d bytes but got %d", len(sent), gotLen)
+			}
+
+			reqs := f.Requests(t)
+			if len(reqs) != 1 {
+				t.Fatalf("expected 1 recorded request but got %d", len(reqs))
+			}
+			if len(reqs[0].Body) != maxRecordedBodyBytes {
+				t.Errorf("expected the recorded body to be capped at %d bytes but got %d", maxRecordedBodyBytes, len(reqs[0].Body))
+			}
+		})
+}
+
+// TestHTTPServerFixture_parallelResetRace guards against a regression where
+// BeforeEach reset the whole shared ring buffer on every subtest's start,
+// which silently lost recordings made by a sibling ParallelRun subtest
+// running concurrently against the same, non-Cloneable fixture instance -
+// each side must see only the requests it itself sent via Do, regardless of
+// how the two subtests interleave.
+func TestHTTPServerFixture_parallelResetRace(t *testing.T) {
+	const requestsPerSubtest = 30
+
+	f := new(HTTPServerFixture)
+	f.Record()
+	f.HandleFunc("/ping", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	recordAll := func(t *testing.T, f *HTTPServerFixture) {
+		for i := 0; i < requestsPerSubtest; i++ {
+			f.Do(t, http.MethodGet, "/ping", nil)
+		}
+
+		if got := len(f.Requests(t)); got != requestsPerSubtest {
+			t.Errorf("expected %d recorded requests but got %d", requestsPerSubtest, got)
+		}
+	}
+
+	With(t, f).
+		ParallelRun("side-a", recordAll).
+		ParallelRun("side-b", recordAll)
+}
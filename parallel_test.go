@@ -0,0 +1,127 @@
+package fixture_test
+
+import (
+	"net/http"
+	"os"
+	"path"
+	"sync"
+	"testing"
+
+	. "github.com/halimath/fixture"
+)
+
+// hookOrderFixture implements Cloneable, BeforeEach and AfterEach, recording
+// the order hooks and the test body itself run in, keyed by the leaf
+// (innermost) segment of t.Name(). Its recorder (mu/events) is shared
+// across every Clone, so a single instance can track several ParallelRun
+// subtests at once.
+type hookOrderFixture struct {
+	mu     *sync.Mutex
+	events map[string][]string
+}
+
+func newHookOrderFixture() *hookOrderFixture {
+	return &hookOrderFixture{mu: &sync.Mutex{}, events: map[string][]string{}}
+}
+
+func (f *hookOrderFixture) record(t *testing.T, event string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	name := path.Base(t.Name())
+	f.events[name] = append(f.events[name], event)
+}
+
+func (f *hookOrderFixture) BeforeEach(t *testing.T) error {
+	f.record(t, "BeforeEach")
+	return nil
+}
+
+func (f *hookOrderFixture) AfterEach(t *testing.T) error {
+	f.record(t, "AfterEach")
+	return nil
+}
+
+func (f *hookOrderFixture) Clone() Fixture {
+	return &hookOrderFixture{mu: f.mu, events: f.events}
+}
+
+func TestSuite_parallelRun(t *testing.T) {
+	srv := new(HTTPServerFixture)
+	srv.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	dir := TempDir("parallel_run_test")
+
+	f := Tuple(srv, dir)
+
+	var seenDirs sync.Map
+
+	t.Run("test", func(t *testing.T) {
+		s := With(t, f)
+
+		for _, name := range []string{"a", "b", "c"} {
+			name := name
+			s.ParallelRun(name, func(t *testing.T, f *TupleFixture[*HTTPServerFixture, *TempDirFixture]) {
+				if _, loaded := seenDirs.LoadOrStore(f.Two.Path(), true); loaded {
+					t.Errorf("expected a unique temp dir per parallel subtest but %s was reused", f.Two.Path())
+				}
+
+				if _, err := os.Stat(f.Two.Path()); err != nil {
+					t.Errorf("expected cloned temp dir to exist: %s", err)
+				}
+
+				res, err := http.Get(f.One.URL())
+				if err != nil {
+					t.Fatal(err)
+				}
+				if res.StatusCode != http.StatusOK {
+					t.Errorf("expected 200 but got %d", res.StatusCode)
+				}
+			})
+		}
+	})
+
+	n := 0
+	seenDirs.Range(func(_, _ any) bool {
+		n++
+		return true
+	})
+	if n != 3 {
+		t.Errorf("expected 3 unique temp dirs but got %d", n)
+	}
+}
+
+// TestSuite_parallelRun_hookOrdering asserts that BeforeEach and AfterEach
+// still run, in order, around each ParallelRun subtest's test body - i.e.
+// BeforeEach runs after t.Parallel() lets the subtest resume, and AfterEach
+// (registered via t.Cleanup) runs once that subtest's body has returned.
+func TestSuite_parallelRun_hookOrdering(t *testing.T) {
+	f := newHookOrderFixture()
+
+	t.Run("test", func(t *testing.T) {
+		s := With(t, f)
+
+		for _, name := range []string{"a", "b", "c"} {
+			name := name
+			s.ParallelRun(name, func(t *testing.T, f *hookOrderFixture) {
+				f.record(t, "Test")
+			})
+		}
+	})
+
+	for _, name := range []string{"a", "b", "c"} {
+		got := f.events[name]
+		want := []string{"BeforeEach", "Test", "AfterEach"}
+
+		if len(got) != len(want) {
+			t.Fatalf("%s: expected hook order %v but got %v", name, want, got)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%s: expected hook order %v but got %v", name, want, got)
+				break
+			}
+		}
+	}
+}
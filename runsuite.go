@@ -0,0 +1,145 @@
+package fixture
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var testingTType = reflect.TypeOf((*testing.T)(nil))
+
+// RunSuite discovers every exported method on suite whose name starts with
+// "Test" and whose signature is either func(t *testing.T) or
+// func(t *testing.T, f F) and runs each one as a subtest via t.Run, using
+// the method name as the subtest name. This mirrors gocheck's
+// SetUpSuite/SetUpTest/TearDownTest/TearDownSuite semantics: BeforeAll and
+// AfterAll wrap the whole discovered set while BeforeEach and AfterEach wrap
+// each discovered method. Unlike Suite.Run, RunSuite does not require the
+// tests to be chained manually, which makes it a better fit for suites with
+// many test methods that want to share helpers and private state.
+//
+// If BeforeAll or BeforeEach return an error, the affected subtests are
+// skipped via t.Skipf rather than aborting the whole suite.
+func RunSuite[F Fixture](t *testing.T, suite F) {
+	t.Helper()
+
+	v := reflect.ValueOf(suite)
+	methods := discoverTestMethods(v.Type())
+
+	var fix any = suite
+
+	var beforeAllErr error
+	if bac, ok := fix.(BeforeAllContext); ok {
+		beforeAllErr = bac.BeforeAllContext(deadlineContext(t), t)
+	} else if ba, ok := fix.(BeforeAll); ok {
+		beforeAllErr = ba.BeforeAll(t)
+	}
+
+	if aac, ok := fix.(AfterAllContext); ok {
+		ctx := deadlineContext(t)
+		t.Cleanup(func() {
+			t.Helper()
+			if err := aac.AfterAllContext(ctx, t); err != nil {
+				t.Fatal(err)
+			}
+		})
+	} else if aa, ok := fix.(AfterAll); ok {
+		t.Cleanup(func() {
+			t.Helper()
+			if err := aa.AfterAll(t); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+
+	for _, m := range methods {
+		m := m
+
+		t.Run(m.Name, func(t *testing.T) {
+			t.Helper()
+
+			if beforeAllErr != nil {
+				t.Skipf("BeforeAll failed: %s", beforeAllErr)
+			}
+
+			if bec, ok := fix.(BeforeEachContext); ok {
+				if err := bec.BeforeEachContext(deadlineContext(t), t); err != nil {
+					t.Skipf("BeforeEach failed: %s", err)
+				}
+			} else if be, ok := fix.(BeforeEach); ok {
+				if err := be.BeforeEach(t); err != nil {
+					t.Skipf("BeforeEach failed: %s", err)
+				}
+			}
+
+			if aec, ok := fix.(AfterEachContext); ok {
+				ctx := deadlineContext(t)
+				t.Cleanup(func() {
+					t.Helper()
+					if err := aec.AfterEachContext(ctx, t); err != nil {
+						t.Fatal(err)
+					}
+				})
+			} else if ae, ok := fix.(AfterEach); ok {
+				t.Cleanup(func() {
+					t.Helper()
+					if err := ae.AfterEach(t); err != nil {
+						t.Fatal(err)
+					}
+				})
+			}
+
+			invokeTestMethod(t, v, m, suite)
+		})
+	}
+}
+
+// discoverTestMethods returns all methods of typ whose name starts with
+// "Test" and that match one of the supported test method signatures.
+func discoverTestMethods(typ reflect.Type) []reflect.Method {
+	var methods []reflect.Method
+
+	for i := 0; i < typ.NumMethod(); i++ {
+		m := typ.Method(i)
+		if !strings.HasPrefix(m.Name, "Test") {
+			continue
+		}
+		if !isTestMethodSignature(m.Type, typ) {
+			continue
+		}
+		methods = append(methods, m)
+	}
+
+	return methods
+}
+
+// isTestMethodSignature reports whether ft - a method's function type
+// including its receiver as the first argument - matches
+// func(t *testing.T) or func(t *testing.T, f recv).
+func isTestMethodSignature(ft, recv reflect.Type) bool {
+	if ft.NumOut() != 0 || ft.IsVariadic() {
+		return false
+	}
+
+	switch ft.NumIn() {
+	case 2:
+		return ft.In(1) == testingTType
+	case 3:
+		return ft.In(1) == testingTType && ft.In(2) == recv
+	default:
+		return false
+	}
+}
+
+// invokeTestMethod calls m on v passing t and, if the method declares a
+// second parameter, suite itself.
+func invokeTestMethod(t *testing.T, v reflect.Value, m reflect.Method, suite any) {
+	t.Helper()
+
+	args := []reflect.Value{v, reflect.ValueOf(t)}
+	if m.Func.Type().NumIn() == 3 {
+		args = append(args, reflect.ValueOf(suite))
+	}
+
+	m.Func.Call(args)
+}
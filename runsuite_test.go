@@ -0,0 +1,87 @@
+package fixture_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/halimath/fixture"
+)
+
+type methodSuite struct {
+	b *strings.Builder
+}
+
+func (s *methodSuite) BeforeAll(t *testing.T) error {
+	s.b.WriteString("BeforeAll;")
+	return nil
+}
+
+func (s *methodSuite) AfterAll(t *testing.T) error {
+	s.b.WriteString("AfterAll;")
+	return nil
+}
+
+func (s *methodSuite) BeforeEach(t *testing.T) error {
+	s.b.WriteString("BeforeEach;")
+	return nil
+}
+
+func (s *methodSuite) AfterEach(t *testing.T) error {
+	s.b.WriteString("AfterEach;")
+	return nil
+}
+
+func (s *methodSuite) helper() string {
+	return "not a test"
+}
+
+func (s *methodSuite) TestOne(t *testing.T) {
+	s.b.WriteString("TestOne;")
+}
+
+func (s *methodSuite) TestTwo(t *testing.T, f *methodSuite) {
+	if f != s {
+		t.Fatal("expected the suite itself to be passed as the second argument")
+	}
+	s.b.WriteString("TestTwo;")
+}
+
+func TestRunSuite(t *testing.T) {
+	var b strings.Builder
+	s := &methodSuite{b: &b}
+
+	t.Run("suite", func(t *testing.T) {
+		RunSuite(t, s)
+	})
+
+	want := "BeforeAll;BeforeEach;TestOne;AfterEach;BeforeEach;TestTwo;AfterEach;AfterAll;"
+	got := b.String()
+
+	if want != got {
+		t.Errorf("expected %q but got %q", want, got)
+	}
+}
+
+func TestRunSuite_skipsOnBeforeEachError(t *testing.T) {
+	s := &failingEachSuite{}
+
+	t.Run("suite", func(t *testing.T) {
+		RunSuite(t, s)
+	})
+}
+
+type failingEachSuite struct{}
+
+func (s *failingEachSuite) BeforeEach(t *testing.T) error {
+	return errSkip
+}
+
+func (s *failingEachSuite) TestSkipped(t *testing.T) {
+	t.Fatal("test should have been skipped")
+}
+
+var errSkip = &skipError{"before each failed"}
+
+type skipError struct{ msg string }
+
+func (e *skipError) Error() string { return e.msg }
@@ -1,6 +1,7 @@
 package fixture
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -17,7 +18,14 @@ func TempDir(name string) *TempDirFixture {
 	}
 }
 
-func (f *TempDirFixture) BeforeAll(t *testing.T) error {
+// BeforeAllContext implements fixture.BeforeAllContext. It bails out early
+// if ctx has already expired rather than creating a directory nobody will
+// use.
+func (f *TempDirFixture) BeforeAllContext(ctx context.Context, t *testing.T) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	path, err := os.MkdirTemp("", f.name)
 	if err != nil {
 		return fmt.Errorf("failed to create temporary test directory %s: %w", f.name, err)
@@ -26,7 +34,10 @@ func (f *TempDirFixture) BeforeAll(t *testing.T) error {
 	return nil
 }
 
-func (f *TempDirFixture) AfterAll(t *testing.T) error {
+// AfterAllContext implements fixture.AfterAllContext. The directory is
+// always removed, even if ctx has already expired, since teardown must run
+// regardless of the deadline that triggered it.
+func (f *TempDirFixture) AfterAllContext(ctx context.Context, t *testing.T) error {
 	if err := os.RemoveAll(f.path); err != nil {
 		return fmt.Errorf("failed to remove temporary test directory %s: %w", f.path, err)
 	}
@@ -51,3 +62,20 @@ func (f *TempDirFixture) Join(parts ...string) string {
 	copy(p[1:], parts)
 	return filepath.Join(p...)
 }
+
+// Clone implements fixture.Cloneable. It creates a fresh subdirectory of the
+// directory created in BeforeAll so parallel subtests started via
+// ParallelRun each get their own, isolated directory. If creating the
+// subdirectory fails, the original fixture is returned, i.e. the directory
+// is shared for that subtest.
+func (f *TempDirFixture) Clone() Fixture {
+	path, err := os.MkdirTemp(f.path, "")
+	if err != nil {
+		return f
+	}
+
+	return &TempDirFixture{
+		name: f.name,
+		path: path,
+	}
+}